@@ -0,0 +1,340 @@
+package lazystream
+
+import (
+	"bufio"
+	"errors"
+	"iter"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// CacheOptions configures CacheWith.
+type CacheOptions struct {
+	// Limit caps how many elements are memoized; zero means no limit. The
+	// first reader to go past the limit keeps pulling from the original
+	// source iteration without recording. Every other reader that goes past
+	// it iterates the source again from the start and skips the first Limit
+	// elements, so with a Limit the source must be re-iterable.
+	Limit int
+}
+
+// CacheWith returns a Stream that memoizes s lazily. Nothing is pulled until
+// the first iteration; later iterations replay the recorded prefix and only
+// pull from s when they need elements nobody has seen yet. It is safe to
+// iterate the returned Stream from several goroutines at once.
+func (s *Stream[T]) CacheWith(opts CacheOptions) *Stream[T] {
+	c := newCache(s._seq, opts.Limit, &memoryStore[T]{})
+	return &Stream[T]{func(yield func(T) bool) {
+		// The memory store cannot fail.
+		_ = c.read(yield)
+	}}
+}
+
+// DiskCacheOptions configures DiskCache. The zero value spills to os.TempDir
+// using gob.
+type DiskCacheOptions[T any] struct {
+	Dir   string
+	Codec RunCodec[T]
+}
+
+var ErrCacheReleased = errors.New("lazystream: cache released")
+
+// DiskCache is like CacheWith but records elements in a temporary file instead
+// of memory. I/O failures are reported as a trailing error Result.
+//
+// Call release when done to stop the source and remove the file; iterating
+// afterwards yields ErrCacheReleased. release must not be called while the
+// stream is being iterated. If it is never called, the file is removed only
+// when the cache is garbage collected, which may never happen before exit.
+func DiskCache[T any](s *Stream[T], opts DiskCacheOptions[T]) (stream *Stream[Result[T]], release func()) {
+	codec := opts.Codec
+	if codec.NewEncoder == nil || codec.NewDecoder == nil {
+		codec = GobCodec[T]()
+	}
+	c := newCache(s._seq, 0, &diskStore[T]{dir: opts.Dir, codec: codec})
+	stream = &Stream[Result[T]]{func(yield func(Result[T]) bool) {
+		more := true
+		err := c.read(func(item T) bool {
+			more = yield(Ok(item))
+			return more
+		})
+		if err != nil && more {
+			yield(Err[T](err))
+		}
+	}}
+	return stream, c.release
+}
+
+type cacheStore[T any] interface {
+	append(T) error
+	cursor() cacheCursor[T]
+	close()
+}
+
+// cacheCursor reads recorded elements in order, one index at a time.
+type cacheCursor[T any] interface {
+	get(i int) (T, error)
+	close()
+}
+
+type cache[T any] struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	seq     iter.Seq[T]
+	pull    *pulled[T]
+	pulling bool
+	done    bool
+	full    bool
+	claimed bool
+	err     error
+	n       int
+	limit   int
+	store   cacheStore[T]
+}
+
+func newCache[T any](seq iter.Seq[T], limit int, store cacheStore[T]) *cache[T] {
+	c := &cache[T]{seq: seq, pull: &pulled[T]{}, limit: limit, store: store}
+	c.cond = sync.NewCond(&c.mu)
+	// Readers may stop early, leaving the source suspended mid-iteration.
+	runtime.AddCleanup(c, func(c cacheCleanup[T]) {
+		if c.pull.stop != nil {
+			c.pull.stop()
+		}
+		c.store.close()
+	}, cacheCleanup[T]{c.pull, store})
+	return c
+}
+
+type cacheCleanup[T any] struct {
+	pull  *pulled[T]
+	store cacheStore[T]
+}
+
+// release stops the source and frees the store. Later reads fail with
+// ErrCacheReleased.
+func (c *cache[T]) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == ErrCacheReleased {
+		return
+	}
+	if c.pull.stop != nil {
+		c.pull.stop()
+	}
+	c.store.close()
+	c.err = ErrCacheReleased
+	c.n = 0
+}
+
+func (c *cache[T]) read(yield func(T) bool) error {
+	cursor := c.store.cursor()
+	defer cursor.close()
+	for i := 0; ; {
+		c.mu.Lock()
+		for i == c.n && c.pulling {
+			c.cond.Wait()
+		}
+		if i == c.n {
+			switch {
+			case c.err != nil:
+				err := c.err
+				c.mu.Unlock()
+				return err
+			case c.done:
+				c.mu.Unlock()
+				return nil
+			}
+			if c.full {
+				return c.readPastLimit(yield)
+			}
+			c.fill()
+			c.mu.Unlock()
+			continue
+		}
+		c.mu.Unlock()
+		item, err := cursor.get(i)
+		if err != nil {
+			return err
+		}
+		if !yield(item) {
+			return nil
+		}
+		i++
+	}
+}
+
+// readPastLimit serves a reader that has replayed the whole recorded prefix of
+// a full cache. It is called with c.mu held and releases it.
+func (c *cache[T]) readPastLimit(yield func(T) bool) error {
+	if !c.claimed {
+		// Hand the live source iteration to this reader alone.
+		c.claimed = true
+		c.mu.Unlock()
+		defer c.pull.stop()
+		for {
+			item, ok := c.pull.next()
+			if !ok || !yield(item) {
+				return nil
+			}
+		}
+	}
+	skip := c.n
+	c.mu.Unlock()
+	for item := range c.seq {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if !yield(item) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// fill pulls one element from the source and records it. It is called with
+// c.mu held and releases it while the source runs.
+func (c *cache[T]) fill() {
+	if c.pull.next == nil {
+		c.pull.next, c.pull.stop = iter.Pull(c.seq)
+	}
+	c.pulling = true
+	c.mu.Unlock()
+	item, ok := c.pull.next()
+	c.mu.Lock()
+	c.pulling = false
+	defer c.cond.Broadcast()
+	if !ok {
+		c.done = true
+		c.pull.stop()
+		return
+	}
+	if err := c.store.append(item); err != nil {
+		c.err = err
+		c.pull.stop()
+		return
+	}
+	c.n++
+	if c.limit > 0 && c.n >= c.limit {
+		c.full = true
+	}
+}
+
+type memoryStore[T any] struct {
+	mu    sync.RWMutex
+	items []T
+}
+
+func (m *memoryStore[T]) append(item T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = append(m.items, item)
+	return nil
+}
+
+func (m *memoryStore[T]) cursor() cacheCursor[T] {
+	return memoryCursor[T]{m}
+}
+
+func (m *memoryStore[T]) close() {}
+
+type memoryCursor[T any] struct {
+	store *memoryStore[T]
+}
+
+func (c memoryCursor[T]) get(i int) (T, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+	return c.store.items[i], nil
+}
+
+func (c memoryCursor[T]) close() {}
+
+type diskStore[T any] struct {
+	mu      sync.Mutex
+	dir     string
+	codec   RunCodec[T]
+	file    *os.File
+	w       *bufio.Writer
+	encode  func(T) error
+	written int
+	flushed int
+}
+
+func (d *diskStore[T]) append(item T) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.file == nil {
+		file, err := os.CreateTemp(d.dir, "lazystream-cache-*")
+		if err != nil {
+			return err
+		}
+		d.file = file
+		d.w = bufio.NewWriter(file)
+		d.encode = d.codec.NewEncoder(d.w)
+	}
+	if err := d.encode(item); err != nil {
+		return err
+	}
+	d.written++
+	return nil
+}
+
+// flushThrough makes sure element i has reached the file and returns its name.
+func (d *diskStore[T]) flushThrough(i int) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.file == nil {
+		return "", ErrCacheReleased
+	}
+	if i >= d.flushed {
+		if err := d.w.Flush(); err != nil {
+			return "", err
+		}
+		d.flushed = d.written
+	}
+	return d.file.Name(), nil
+}
+
+func (d *diskStore[T]) cursor() cacheCursor[T] {
+	return &diskCursor[T]{store: d}
+}
+
+func (d *diskStore[T]) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.file != nil {
+		d.file.Close()
+		os.Remove(d.file.Name())
+		d.file = nil
+	}
+}
+
+type diskCursor[T any] struct {
+	store  *diskStore[T]
+	file   *os.File
+	decode func() (T, error)
+}
+
+func (c *diskCursor[T]) get(i int) (T, error) {
+	name, err := c.store.flushThrough(i)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if c.file == nil {
+		if c.file, err = os.Open(name); err != nil {
+			var zero T
+			return zero, err
+		}
+		c.decode = c.store.codec.NewDecoder(bufio.NewReader(c.file))
+	}
+	return c.decode()
+}
+
+func (c *diskCursor[T]) close() {
+	if c.file != nil {
+		c.file.Close()
+	}
+}
@@ -0,0 +1,60 @@
+package lazystream
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestCacheLimitIteratesTwice(t *testing.T) {
+	want := []int{0, 1, 2, 3, 4, 5}
+	s := Range(0, 6, 1).CacheWith(CacheOptions{Limit: 2})
+	for pass := range 2 {
+		if got := s.List(); !slices.Equal(got, want) {
+			t.Fatalf("pass %d: List() = %v, want %v", pass, got, want)
+		}
+	}
+}
+
+func TestCacheLimitAfterEarlyStop(t *testing.T) {
+	want := []int{0, 1, 2, 3, 4, 5}
+	s := Range(0, 6, 1).CacheWith(CacheOptions{Limit: 2})
+	if got := s.Take(4).List(); !slices.Equal(got, want[:4]) {
+		t.Fatalf("Take(4) = %v, want %v", got, want[:4])
+	}
+	if got := s.List(); !slices.Equal(got, want) {
+		t.Fatalf("List() = %v, want %v", got, want)
+	}
+}
+
+func TestCacheLimitSingleUseFirstPass(t *testing.T) {
+	ch := make(chan int, 6)
+	for i := range 6 {
+		ch <- i
+	}
+	close(ch)
+	s := FromChannel(ch).CacheWith(CacheOptions{Limit: 2})
+	if got, want := s.List(), []int{0, 1, 2, 3, 4, 5}; !slices.Equal(got, want) {
+		t.Fatalf("List() = %v, want %v", got, want)
+	}
+}
+
+func TestCacheLimitConcurrentReaders(t *testing.T) {
+	want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	s := Range(0, 10, 1).CacheWith(CacheOptions{Limit: 3})
+	var wg sync.WaitGroup
+	results := make([][]int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.List()
+		}()
+	}
+	wg.Wait()
+	for i, got := range results {
+		if !slices.Equal(got, want) {
+			t.Errorf("reader %d: List() = %v, want %v", i, got, want)
+		}
+	}
+}
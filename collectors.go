@@ -0,0 +1,253 @@
+package lazystream
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Collector describes a mutable reduction in the style of java.util.stream:
+// Supplier creates an empty accumulation, Accumulator folds one element into
+// it, Combiner merges two partial accumulations and Finisher turns the final
+// accumulation into the result. Combiner is not used by Collect but lets
+// collectors run over independently accumulated partitions.
+type Collector[T, A, R any] struct {
+	Supplier    func() A
+	Accumulator func(A, T) A
+	Combiner    func(A, A) A
+	Finisher    func(A) R
+}
+
+// NOTE: Fix when MethodGenerics are supported
+func Collect[T, A, R any](s *Stream[T], c Collector[T, A, R]) R {
+	acc := c.Supplier()
+	for item := range s._seq {
+		acc = c.Accumulator(acc, item)
+	}
+	return c.Finisher(acc)
+}
+
+func ToSlice[T any]() Collector[T, []T, []T] {
+	return Collector[T, []T, []T]{
+		Supplier:    func() []T { return nil },
+		Accumulator: func(acc []T, item T) []T { return append(acc, item) },
+		Combiner:    func(a, b []T) []T { return append(a, b...) },
+		Finisher:    Identity[[]T],
+	}
+}
+
+func ToSet[T comparable]() Collector[T, map[T]bool, map[T]bool] {
+	return Collector[T, map[T]bool, map[T]bool]{
+		Supplier: func() map[T]bool { return make(map[T]bool) },
+		Accumulator: func(acc map[T]bool, item T) map[T]bool {
+			acc[item] = true
+			return acc
+		},
+		Combiner: func(a, b map[T]bool) map[T]bool {
+			for item := range b {
+				a[item] = true
+			}
+			return a
+		},
+		Finisher: Identity[map[T]bool],
+	}
+}
+
+// ToMapBy collects elements into a map using keyFunc and valueFunc. Later
+// elements overwrite earlier ones with the same key.
+func ToMapBy[T any, K comparable, V any](keyFunc func(T) K, valueFunc func(T) V) Collector[T, map[K]V, map[K]V] {
+	return Collector[T, map[K]V, map[K]V]{
+		Supplier: func() map[K]V { return make(map[K]V) },
+		Accumulator: func(acc map[K]V, item T) map[K]V {
+			acc[keyFunc(item)] = valueFunc(item)
+			return acc
+		},
+		Combiner: func(a, b map[K]V) map[K]V {
+			for k, v := range b {
+				a[k] = v
+			}
+			return a
+		},
+		Finisher: Identity[map[K]V],
+	}
+}
+
+// GroupingBy groups elements by keyFunc and reduces each group with downstream.
+func GroupingBy[T any, K comparable, A, R any](keyFunc func(T) K, downstream Collector[T, A, R]) Collector[T, map[K]A, map[K]R] {
+	return Collector[T, map[K]A, map[K]R]{
+		Supplier: func() map[K]A { return make(map[K]A) },
+		Accumulator: func(acc map[K]A, item T) map[K]A {
+			key := keyFunc(item)
+			group, ok := acc[key]
+			if !ok {
+				group = downstream.Supplier()
+			}
+			acc[key] = downstream.Accumulator(group, item)
+			return acc
+		},
+		Combiner: func(a, b map[K]A) map[K]A {
+			for key, group := range b {
+				if existing, ok := a[key]; ok {
+					a[key] = downstream.Combiner(existing, group)
+				} else {
+					a[key] = group
+				}
+			}
+			return a
+		},
+		Finisher: func(acc map[K]A) map[K]R {
+			result := make(map[K]R, len(acc))
+			for key, group := range acc {
+				result[key] = downstream.Finisher(group)
+			}
+			return result
+		},
+	}
+}
+
+// PartitioningBy splits elements on predicate and reduces both sides with
+// downstream. The result always has a true and a false entry.
+func PartitioningBy[T, A, R any](predicate func(T) bool, downstream Collector[T, A, R]) Collector[T, Pair[A, A], map[bool]R] {
+	return Collector[T, Pair[A, A], map[bool]R]{
+		Supplier: func() Pair[A, A] {
+			return Pair[A, A]{Left: downstream.Supplier(), Right: downstream.Supplier()}
+		},
+		Accumulator: func(acc Pair[A, A], item T) Pair[A, A] {
+			if predicate(item) {
+				acc.Left = downstream.Accumulator(acc.Left, item)
+			} else {
+				acc.Right = downstream.Accumulator(acc.Right, item)
+			}
+			return acc
+		},
+		Combiner: func(a, b Pair[A, A]) Pair[A, A] {
+			return Pair[A, A]{
+				Left:  downstream.Combiner(a.Left, b.Left),
+				Right: downstream.Combiner(a.Right, b.Right),
+			}
+		},
+		Finisher: func(acc Pair[A, A]) map[bool]R {
+			return map[bool]R{
+				true:  downstream.Finisher(acc.Left),
+				false: downstream.Finisher(acc.Right),
+			}
+		},
+	}
+}
+
+// Joining formats each element with fmt.Sprint and joins them with separator.
+func Joining[T any](separator string) Collector[T, []string, string] {
+	return Collector[T, []string, string]{
+		Supplier:    func() []string { return nil },
+		Accumulator: func(acc []string, item T) []string { return append(acc, fmt.Sprint(item)) },
+		Combiner:    func(a, b []string) []string { return append(a, b...) },
+		Finisher:    func(acc []string) string { return strings.Join(acc, separator) },
+	}
+}
+
+func Counting[T any]() Collector[T, int, int] {
+	return Collector[T, int, int]{
+		Supplier:    func() int { return 0 },
+		Accumulator: func(n int, _ T) int { return n + 1 },
+		Combiner:    func(a, b int) int { return a + b },
+		Finisher:    Identity[int],
+	}
+}
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Summary holds the count, sum, minimum and maximum of a set of numbers. Min
+// and Max are zero when Count is zero.
+type Summary[T Number] struct {
+	Count int
+	Sum   T
+	Min   T
+	Max   T
+}
+
+func (s Summary[T]) Average() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Sum) / float64(s.Count)
+}
+
+func (s Summary[T]) merge(other Summary[T]) Summary[T] {
+	if other.Count == 0 {
+		return s
+	}
+	if s.Count == 0 {
+		return other
+	}
+	return Summary[T]{
+		Count: s.Count + other.Count,
+		Sum:   s.Sum + other.Sum,
+		Min:   min(s.Min, other.Min),
+		Max:   max(s.Max, other.Max),
+	}
+}
+
+func Summarizing[T Number]() Collector[T, Summary[T], Summary[T]] {
+	return Collector[T, Summary[T], Summary[T]]{
+		Supplier: func() Summary[T] { return Summary[T]{} },
+		Accumulator: func(acc Summary[T], item T) Summary[T] {
+			return acc.merge(Summary[T]{Count: 1, Sum: item, Min: item, Max: item})
+		},
+		Combiner: Summary[T].merge,
+		Finisher: Identity[Summary[T]],
+	}
+}
+
+// Mapping applies mapper to each element before handing it to downstream.
+func Mapping[T, U, A, R any](mapper func(T) U, downstream Collector[U, A, R]) Collector[T, A, R] {
+	return Collector[T, A, R]{
+		Supplier: downstream.Supplier,
+		Accumulator: func(acc A, item T) A {
+			return downstream.Accumulator(acc, mapper(item))
+		},
+		Combiner: downstream.Combiner,
+		Finisher: downstream.Finisher,
+	}
+}
+
+// Filtering only hands elements matching predicate to downstream.
+func Filtering[T, A, R any](predicate func(T) bool, downstream Collector[T, A, R]) Collector[T, A, R] {
+	return Collector[T, A, R]{
+		Supplier: downstream.Supplier,
+		Accumulator: func(acc A, item T) A {
+			if predicate(item) {
+				return downstream.Accumulator(acc, item)
+			}
+			return acc
+		},
+		Combiner: downstream.Combiner,
+		Finisher: downstream.Finisher,
+	}
+}
+
+// Teeing feeds every element to both collectors and merges their results.
+func Teeing[T, A1, R1, A2, R2, R any](first Collector[T, A1, R1], second Collector[T, A2, R2], merger func(R1, R2) R) Collector[T, Pair[A1, A2], R] {
+	return Collector[T, Pair[A1, A2], R]{
+		Supplier: func() Pair[A1, A2] {
+			return Pair[A1, A2]{Left: first.Supplier(), Right: second.Supplier()}
+		},
+		Accumulator: func(acc Pair[A1, A2], item T) Pair[A1, A2] {
+			return Pair[A1, A2]{
+				Left:  first.Accumulator(acc.Left, item),
+				Right: second.Accumulator(acc.Right, item),
+			}
+		},
+		Combiner: func(a, b Pair[A1, A2]) Pair[A1, A2] {
+			return Pair[A1, A2]{
+				Left:  first.Combiner(a.Left, b.Left),
+				Right: second.Combiner(a.Right, b.Right),
+			}
+		},
+		Finisher: func(acc Pair[A1, A2]) R {
+			return merger(first.Finisher(acc.Left), second.Finisher(acc.Right))
+		},
+	}
+}
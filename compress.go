@@ -0,0 +1,81 @@
+package lazystream
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FromCompressedFile streams the decompressed tokens of a gzip, bzip2 or zlib
+// file. The format is picked from the extension (.gz, .bz2, .zz, .zlib) and
+// otherwise sniffed from the magic bytes; anything else is read as plain text.
+// The file is reopened on every iteration.
+func FromCompressedFile(path string, opts ScanOptions) *Stream[Result[string]] {
+	return &Stream[Result[string]]{func(yield func(Result[string]) bool) {
+		file, err := os.Open(path)
+		if err != nil {
+			yield(Err[string](err))
+			return
+		}
+		defer file.Close()
+		r, err := decompress(bufio.NewReader(file), filepath.Ext(path))
+		if err != nil {
+			yield(Err[string](err))
+			return
+		}
+		defer r.Close()
+		scanStrings(r, opts, yield)
+	}}
+}
+
+func decompress(r *bufio.Reader, ext string) (io.ReadCloser, error) {
+	switch strings.ToLower(ext) {
+	case ".gz", ".gzip":
+		return gzip.NewReader(r)
+	case ".bz2", ".bzip2":
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case ".zz", ".zlib":
+		return zlib.NewReader(r)
+	}
+	magic, _ := r.Peek(3)
+	switch {
+	case len(magic) >= 2 && magic[0] == 0x1f && magic[1] == 0x8b:
+		return gzip.NewReader(r)
+	case len(magic) >= 3 && string(magic) == "BZh":
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case len(magic) >= 2 && magic[0] == 0x78 && strings.IndexByte("\x01\x5e\x9c\xda", magic[1]) >= 0:
+		return zlib.NewReader(r)
+	}
+	return io.NopCloser(r), nil
+}
+
+// ToGzipFile writes each element on its own line to a gzip-compressed file at
+// path, truncating it if it exists.
+func (s *Stream[T]) ToGzipFile(path string) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	zw := gzip.NewWriter(file)
+	w := bufio.NewWriter(zw)
+	for item := range s._seq {
+		if _, err := fmt.Fprintln(w, item); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
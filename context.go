@@ -0,0 +1,60 @@
+package lazystream
+
+import "context"
+
+// WithContext returns a Stream that stops pulling from the source as soon as
+// ctx is done. Use the Ctx terminal operations to learn why it stopped.
+func (s *Stream[T]) WithContext(ctx context.Context) *Stream[T] {
+	return &Stream[T]{func(yield func(T) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+		for item := range s._seq {
+			if ctx.Err() != nil {
+				return
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	}}
+}
+
+// ForEachCtx executes action on each element until the stream is exhausted or
+// ctx is done, in which case ctx.Err() is returned. A context cancelled after
+// the last element has been handled does not count as an error.
+func (s *Stream[T]) ForEachCtx(ctx context.Context, action func(T)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for item := range s._seq {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		action(item)
+	}
+	return nil
+}
+
+// ListCtx collects the stream into a slice. If ctx is done before the stream
+// is exhausted, the elements collected so far are returned with ctx.Err().
+func (s *Stream[T]) ListCtx(ctx context.Context) ([]T, error) {
+	var list []T
+	err := s.ForEachCtx(ctx, func(item T) {
+		list = append(list, item)
+	})
+	return list, err
+}
+
+// NOTE: Fix when MethodGenerics are supported
+func ReduceCtx[T, R any](ctx context.Context, s *Stream[T], reducer func(R, T) R, initial R) (R, error) {
+	result := initial
+	err := s.ForEachCtx(ctx, func(item T) {
+		result = reducer(result, item)
+	})
+	return result, err
+}
+
+func (s *Stream[T]) ReduceCtx(ctx context.Context, reducer func(T, T) T, initial T) (T, error) {
+	return ReduceCtx(ctx, s, reducer, initial)
+}
@@ -0,0 +1,237 @@
+package lazystream
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// CSVOptions mirrors the knobs of encoding/csv. The zero value reads and writes
+// standard comma-separated records.
+type CSVOptions struct {
+	Comma            rune
+	Comment          rune
+	FieldsPerRecord  int
+	LazyQuotes       bool
+	TrimLeadingSpace bool
+}
+
+func (opts CSVOptions) reader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	reader.Comment = opts.Comment
+	reader.FieldsPerRecord = opts.FieldsPerRecord
+	reader.LazyQuotes = opts.LazyQuotes
+	reader.TrimLeadingSpace = opts.TrimLeadingSpace
+	return reader
+}
+
+// FromCSV yields the records of r. Malformed rows are reported as error Results
+// and reading continues with the next row; other read errors end the stream.
+func FromCSV(r io.Reader, opts CSVOptions) *Stream[Result[[]string]] {
+	return &Stream[Result[[]string]]{func(yield func(Result[[]string]) bool) {
+		reader := opts.reader(r)
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				var parseErr *csv.ParseError
+				if !yield(Err[[]string](err)) || !errors.As(err, &parseErr) {
+					return
+				}
+				continue
+			}
+			if !yield(Ok(record)) {
+				return
+			}
+		}
+	}}
+}
+
+// FromCSVMaps treats the first record of r as a header and yields every
+// following record keyed by column name. A malformed header ends the stream.
+func FromCSVMaps(r io.Reader, opts CSVOptions) *Stream[Result[map[string]string]] {
+	return &Stream[Result[map[string]string]]{func(yield func(Result[map[string]string]) bool) {
+		var header []string
+		for row := range FromCSV(r, opts)._seq {
+			if row.Err != nil {
+				// Without a header there is no way to name the columns.
+				if !yield(Err[map[string]string](row.Err)) || header == nil {
+					return
+				}
+				continue
+			}
+			if header == nil {
+				header = row.Value
+				continue
+			}
+			record := make(map[string]string, len(header))
+			for i, name := range header {
+				if i < len(row.Value) {
+					record[name] = row.Value[i]
+				}
+			}
+			if !yield(Ok(record)) {
+				return
+			}
+		}
+	}}
+}
+
+// FromCSVStructs treats the first record of r as a header and decodes every
+// following record into a T. Fields are matched by their `csv:"name"` tag, or
+// by field name when untagged; `csv:"-"` skips a field. Supported field kinds
+// are strings, bools, integers and floats. A malformed header ends the stream.
+func FromCSVStructs[T any](r io.Reader, opts CSVOptions) *Stream[Result[T]] {
+	return &Stream[Result[T]]{func(yield func(Result[T]) bool) {
+		fields, err := csvFields(reflect.TypeFor[T]())
+		if err != nil {
+			yield(Err[T](err))
+			return
+		}
+		var columns []int
+		for row := range FromCSV(r, opts)._seq {
+			if row.Err != nil {
+				// Without a header there is no way to map the columns.
+				if !yield(Err[T](row.Err)) || columns == nil {
+					return
+				}
+				continue
+			}
+			if columns == nil {
+				columns = make([]int, len(row.Value))
+				for i, name := range row.Value {
+					columns[i] = -1
+					for j, field := range fields {
+						if field.name == name {
+							columns[i] = j
+						}
+					}
+				}
+				continue
+			}
+			var result Result[T]
+			v := reflect.ValueOf(&result.Value).Elem()
+			for i, cell := range row.Value {
+				if i >= len(columns) || columns[i] < 0 {
+					continue
+				}
+				field := fields[columns[i]]
+				if err := setCSVField(v.Field(field.index), cell); err != nil {
+					result.Err = fmt.Errorf("csv: column %q: %w", field.name, err)
+					break
+				}
+			}
+			if !yield(result) {
+				return
+			}
+		}
+	}}
+}
+
+// ToCSV writes each record of s to w with standard CSV quoting.
+func ToCSV(s *Stream[[]string], w io.Writer, opts CSVOptions) error {
+	writer := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		writer.Comma = opts.Comma
+	}
+	for record := range s._seq {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ToCSVStructs writes a header row followed by one record per element, using
+// the same field mapping as FromCSVStructs.
+func ToCSVStructs[T any](s *Stream[T], w io.Writer, opts CSVOptions) error {
+	fields, err := csvFields(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.name
+	}
+	records := Map(s, func(item T) []string {
+		v := reflect.ValueOf(item)
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			record[i] = fmt.Sprint(v.Field(field.index).Interface())
+		}
+		return record
+	})
+	return ToCSV(records.Prepend(header), w, opts)
+}
+
+type csvField struct {
+	name  string
+	index int
+}
+
+func csvFields(t reflect.Type) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: %s is not a struct", t)
+	}
+	var fields []csvField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, csvField{name: name, index: i})
+	}
+	return fields, nil
+}
+
+func setCSVField(v reflect.Value, cell string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package lazystream
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// RunCodec encodes the sorted runs that ExternalSort spills to disk.
+// NewDecoder's function must return io.EOF once the run is exhausted.
+type RunCodec[T any] struct {
+	NewEncoder func(io.Writer) func(T) error
+	NewDecoder func(io.Reader) func() (T, error)
+}
+
+func GobCodec[T any]() RunCodec[T] {
+	return RunCodec[T]{
+		NewEncoder: func(w io.Writer) func(T) error {
+			enc := gob.NewEncoder(w)
+			return func(item T) error { return enc.Encode(&item) }
+		},
+		NewDecoder: func(r io.Reader) func() (T, error) {
+			dec := gob.NewDecoder(r)
+			return func() (T, error) {
+				var item T
+				err := dec.Decode(&item)
+				return item, err
+			}
+		},
+	}
+}
+
+func JSONCodec[T any]() RunCodec[T] {
+	return RunCodec[T]{
+		NewEncoder: func(w io.Writer) func(T) error {
+			enc := json.NewEncoder(w)
+			return func(item T) error { return enc.Encode(item) }
+		},
+		NewDecoder: func(r io.Reader) func() (T, error) {
+			dec := json.NewDecoder(r)
+			return func() (T, error) {
+				var item T
+				err := dec.Decode(&item)
+				return item, err
+			}
+		},
+	}
+}
+
+// ExternalSortOptions configures ExternalSort. The zero value sorts runs of
+// 100,000 elements, spills them to os.TempDir and encodes them with gob.
+type ExternalSortOptions[T any] struct {
+	RunSize int
+	Dir     string
+	Codec   RunCodec[T]
+}
+
+// ExternalSort stably sorts s without holding more than RunSize elements in
+// memory. The input is sorted in runs that are spilled to temporary files and
+// lazily merged back; the temporary files are removed once iteration finishes
+// or stops early. If everything fits in one run, nothing touches the disk.
+// I/O failures are reported as a trailing error Result.
+func ExternalSort[T any](s *Stream[T], cmp func(T, T) int, opts ExternalSortOptions[T]) *Stream[Result[T]] {
+	runSize := opts.RunSize
+	if runSize <= 0 {
+		runSize = 100_000
+	}
+	codec := opts.Codec
+	if codec.NewEncoder == nil || codec.NewDecoder == nil {
+		codec = GobCodec[T]()
+	}
+	return &Stream[Result[T]]{func(yield func(Result[T]) bool) {
+		var paths []string
+		defer func() {
+			for _, path := range paths {
+				os.Remove(path)
+			}
+		}()
+		var buf []T
+		for item := range s._seq {
+			buf = append(buf, item)
+			if len(buf) < runSize {
+				continue
+			}
+			slices.SortStableFunc(buf, cmp)
+			path, err := spillRun(buf, opts.Dir, codec)
+			if path != "" {
+				paths = append(paths, path)
+			}
+			if err != nil {
+				yield(Err[T](err))
+				return
+			}
+			clear(buf)
+			buf = buf[:0]
+		}
+		slices.SortStableFunc(buf, cmp)
+
+		var readErr error
+		runs := make([]iter.Seq[T], 0, len(paths)+1)
+		for _, path := range paths {
+			runs = append(runs, readRun(path, codec, &readErr))
+		}
+		runs = append(runs, slices.Values(buf))
+		for item := range mergeSeqs(cmp, runs) {
+			if readErr != nil {
+				break
+			}
+			if !yield(Ok(item)) {
+				return
+			}
+		}
+		if readErr != nil {
+			yield(Err[T](readErr))
+		}
+	}}
+}
+
+func spillRun[T any](run []T, dir string, codec RunCodec[T]) (path string, err error) {
+	file, err := os.CreateTemp(dir, "lazystream-sort-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	w := bufio.NewWriter(file)
+	encode := codec.NewEncoder(w)
+	for _, item := range run {
+		if err := encode(item); err != nil {
+			return file.Name(), err
+		}
+	}
+	return file.Name(), w.Flush()
+}
+
+func readRun[T any](path string, codec RunCodec[T], readErr *error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		file, err := os.Open(path)
+		if err != nil {
+			*readErr = err
+			return
+		}
+		defer file.Close()
+		decode := codec.NewDecoder(bufio.NewReader(file))
+		for {
+			item, err := decode()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				*readErr = err
+				return
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -2,6 +2,9 @@ package lazystream
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"io"
 	"iter"
 	"os"
 )
@@ -34,6 +37,26 @@ func FromChannel[T any](ch <-chan T) *Stream[T] {
 	}}
 }
 
+// FromChannelCtx is like FromChannel but stops waiting for the next value
+// once ctx is done, so a silent producer cannot block the consumer forever.
+func FromChannelCtx[T any](ctx context.Context, ch <-chan T) *Stream[T] {
+	return &Stream[T]{func(yield func(T) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case val, ok := <-ch:
+				if !ok {
+					return
+				}
+				if !yield(val) {
+					return
+				}
+			}
+		}
+	}}
+}
+
 func FromSeq[T any](seq iter.Seq[T]) *Stream[T] {
 	return &Stream[T]{seq}
 }
@@ -67,3 +90,139 @@ func FromFile(path string) *Stream[string] {
 		}
 	}}
 }
+
+// ScanOptions configures how byte sources are split into tokens.
+type ScanOptions struct {
+	// Split defaults to bufio.ScanLines.
+	Split bufio.SplitFunc
+	// MaxTokenSize defaults to bufio.MaxScanTokenSize. Longer tokens are
+	// reported as bufio.ErrTooLong instead of silently ending the stream.
+	MaxTokenSize int
+}
+
+func (opts ScanOptions) scanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	if opts.Split != nil {
+		scanner.Split(opts.Split)
+	}
+	if opts.MaxTokenSize > 0 {
+		scanner.Buffer(make([]byte, 0, min(opts.MaxTokenSize, 64*1024)), opts.MaxTokenSize)
+	}
+	return scanner
+}
+
+// ScanDelimiter returns a bufio.SplitFunc that splits on delim. The delimiter
+// is not included in the tokens.
+func ScanDelimiter(delim byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, delim); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
+
+func scanTokens[T any](r io.Reader, opts ScanOptions, token func(*bufio.Scanner) T, yield func(Result[T]) bool) {
+	scanner := opts.scanner(r)
+	for scanner.Scan() {
+		if !yield(Ok(token(scanner))) {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		yield(Err[T](err))
+	}
+}
+
+func scanStrings(r io.Reader, opts ScanOptions, yield func(Result[string]) bool) {
+	scanTokens(r, opts, (*bufio.Scanner).Text, yield)
+}
+
+func scanBytes(r io.Reader, opts ScanOptions, yield func(Result[[]byte]) bool) {
+	scanTokens(r, opts, func(scanner *bufio.Scanner) []byte {
+		// The scanner reuses its buffer, so hand out a copy.
+		return bytes.Clone(scanner.Bytes())
+	}, yield)
+}
+
+// FromFileE is like FromFile but reports open and scan errors as a trailing
+// error Result instead of panicking or truncating. The file is reopened on
+// every iteration.
+func FromFileE(path string, opts ScanOptions) *Stream[Result[string]] {
+	return &Stream[Result[string]]{func(yield func(Result[string]) bool) {
+		file, err := os.Open(path)
+		if err != nil {
+			yield(Err[string](err))
+			return
+		}
+		defer file.Close()
+		scanStrings(file, opts, yield)
+	}}
+}
+
+// FromStdinE is like FromStdin but reports scan errors as a trailing error
+// Result.
+func FromStdinE(opts ScanOptions) *Stream[Result[string]] {
+	return &Stream[Result[string]]{func(yield func(Result[string]) bool) {
+		info, err := os.Stdin.Stat()
+		if err != nil {
+			yield(Err[string](err))
+			return
+		}
+		if (info.Mode() & os.ModeCharDevice) == 0 {
+			scanStrings(os.Stdin, opts, yield)
+		}
+	}}
+}
+
+// FromReader splits r into string tokens. The reader is consumed by the first
+// iteration; scan errors are reported as a trailing error Result.
+func FromReader(r io.Reader, opts ScanOptions) *Stream[Result[string]] {
+	return &Stream[Result[string]]{func(yield func(Result[string]) bool) {
+		scanStrings(r, opts, yield)
+	}}
+}
+
+// FromReaderBytes is like FromReader but yields each token as a fresh []byte.
+func FromReaderBytes(r io.Reader, opts ScanOptions) *Stream[Result[[]byte]] {
+	return &Stream[Result[[]byte]]{func(yield func(Result[[]byte]) bool) {
+		scanBytes(r, opts, yield)
+	}}
+}
+
+// FromReadCloser is like FromReader but closes rc once iteration finishes or
+// stops early. A failing Close is reported as a trailing error Result.
+func FromReadCloser(rc io.ReadCloser, opts ScanOptions) *Stream[Result[string]] {
+	return &Stream[Result[string]]{func(yield func(Result[string]) bool) {
+		withClose(rc, yield, func(yield func(Result[string]) bool) {
+			scanStrings(rc, opts, yield)
+		})
+	}}
+}
+
+// FromReadCloserBytes is like FromReaderBytes but closes rc once iteration
+// finishes or stops early.
+func FromReadCloserBytes(rc io.ReadCloser, opts ScanOptions) *Stream[Result[[]byte]] {
+	return &Stream[Result[[]byte]]{func(yield func(Result[[]byte]) bool) {
+		withClose(rc, yield, func(yield func(Result[[]byte]) bool) {
+			scanBytes(rc, opts, yield)
+		})
+	}}
+}
+
+func withClose[T any](c io.Closer, yield func(Result[T]) bool, body func(func(Result[T]) bool)) {
+	stopped := false
+	body(func(item Result[T]) bool {
+		if !yield(item) {
+			stopped = true
+			return false
+		}
+		return true
+	})
+	if err := c.Close(); err != nil && !stopped {
+		yield(Err[T](err))
+	}
+}
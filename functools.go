@@ -38,3 +38,13 @@ func ToPairStream[K, V any](s *Stream2[K, V]) *Stream[Pair[K, V]] {
 		}
 	}}
 }
+
+func FromPairStream[K, V any](s *Stream[Pair[K, V]]) *Stream2[K, V] {
+	return &Stream2[K, V]{func(yield func(K, V) bool) {
+		for pair := range s._seq {
+			if !yield(pair.Left, pair.Right) {
+				return
+			}
+		}
+	}}
+}
@@ -1,18 +1,44 @@
 package lazystream
 
+import (
+	"iter"
+	"runtime"
+)
+
 func (s *Stream[T]) Uncons() (T, *Stream[T]) {
-	ch := s.ToChannel()
-	item, ok := <-ch
+	next, stop := iter.Pull(s._seq)
+	item, ok := next()
 	if !ok {
+		stop()
 		panic("Stream is empty")
 	}
-	return item, FromChannel(ch)
+	pull := &pulled[T]{next, stop}
+	// The rest may never be consumed; release the source once it is dropped.
+	// The cleanup hangs off pull rather than the returned Stream so that it
+	// stays alive for as long as anyone holds the rest's iter.Seq.
+	runtime.AddCleanup(pull, func(stop func()) { stop() }, stop)
+	return item, &Stream[T]{func(yield func(T) bool) {
+		defer pull.stop()
+		for {
+			item, ok := pull.next()
+			if !ok || !yield(item) {
+				return
+			}
+		}
+	}}
+}
+
+type pulled[T any] struct {
+	next func() (T, bool)
+	stop func()
 }
 
 func (s *Stream[T]) Head() T {
 	// all
-	x, _ := s.Uncons()
-	return x
+	for item := range s._seq {
+		return item
+	}
+	panic("Stream is empty")
 }
 
 func (s *Stream[T]) Tail() *Stream[T] {
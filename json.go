@@ -0,0 +1,103 @@
+package lazystream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// FromJSONL decodes each non-blank line of r into a T. Lines that fail to
+// decode are reported as error Results and reading continues; read errors end
+// the stream.
+func FromJSONL[T any](r io.Reader) *Stream[Result[T]] {
+	return &Stream[Result[T]]{func(yield func(Result[T]) bool) {
+		reader := bufio.NewReader(r)
+		for lineNo := 1; ; lineNo++ {
+			line, err := reader.ReadBytes('\n')
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				var result Result[T]
+				if jerr := json.Unmarshal(line, &result.Value); jerr != nil {
+					result.Err = fmt.Errorf("jsonl: line %d: %w", lineNo, jerr)
+				}
+				if !yield(result) {
+					return
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Err[T](err))
+				return
+			}
+		}
+	}}
+}
+
+// FromJSONArray streams the elements of a top-level JSON array in r without
+// loading the whole document. Decoding stops at the first error.
+func FromJSONArray[T any](r io.Reader) *Stream[Result[T]] {
+	return &Stream[Result[T]]{func(yield func(Result[T]) bool) {
+		dec := json.NewDecoder(r)
+		tok, err := dec.Token()
+		if err != nil {
+			yield(Err[T](err))
+			return
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			yield(Err[T](fmt.Errorf("json: expected array, got %v", tok)))
+			return
+		}
+		for dec.More() {
+			var item T
+			if err := dec.Decode(&item); err != nil {
+				yield(Err[T](err))
+				return
+			}
+			if !yield(Ok(item)) {
+				return
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			yield(Err[T](err))
+		}
+	}}
+}
+
+// ToJSONL encodes each element as JSON on its own line.
+func (s *Stream[T]) ToJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for item := range s._seq {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToJSONArray encodes the stream as a single JSON array, one element at a time.
+func (s *Stream[T]) ToJSONArray(w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	first := true
+	for item := range s._seq {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
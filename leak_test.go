@@ -0,0 +1,144 @@
+package lazystream
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// assertNoLeaks runs fn and fails if goroutines started by it are still alive
+// once the garbage collector has had a chance to run pending cleanups.
+func assertNoLeaks(t *testing.T, fn func()) {
+	t.Helper()
+	runtime.GC()
+	baseline := runtime.NumGoroutine()
+	fn()
+	var current int
+	for range 50 {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+		if current = runtime.NumGoroutine(); current <= baseline {
+			return
+		}
+	}
+	t.Fatalf("goroutines leaked: baseline %d, now %d", baseline, current)
+}
+
+func TestHeadDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 100 {
+			if got := Count(0, 1).Head(); got != 0 {
+				t.Fatalf("Head() = %d, want 0", got)
+			}
+		}
+	})
+}
+
+func TestTailDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 100 {
+			got := Count(0, 1).Tail().Take(3).List()
+			if len(got) != 3 || got[0] != 1 {
+				t.Fatalf("Tail().Take(3) = %v, want [1 2 3]", got)
+			}
+		}
+	})
+}
+
+func TestUnconsConsumedDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 100 {
+			x, xs := Range(0, 5, 1).Uncons()
+			if rest := xs.List(); x != 0 || len(rest) != 4 {
+				t.Fatalf("Uncons() = %d, %v", x, rest)
+			}
+		}
+	})
+}
+
+func TestUnconsDroppedDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 100 {
+			x, _ := Count(0, 1).Uncons()
+			if x != 0 {
+				t.Fatalf("Uncons() head = %d, want 0", x)
+			}
+		}
+	})
+}
+
+func TestUnconsRestIterOutlivesStream(t *testing.T) {
+	assertNoLeaks(t, func() {
+		_, xs := Count(0, 1).Uncons()
+		seq := xs.Iter()
+		xs = nil
+		want := 1
+		for got := range seq {
+			runtime.GC()
+			if got != want {
+				t.Fatalf("rest yielded %d, want %d", got, want)
+			}
+			if want++; want > 5 {
+				break
+			}
+		}
+	})
+}
+
+func TestZipDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 100 {
+			got := ToPairStream(Zip(Count(0, 1), Count(10, 1))).Take(3).List()
+			if len(got) != 3 || got[2] != (Pair[int, int]{2, 12}) {
+				t.Fatalf("Zip = %v", got)
+			}
+		}
+	})
+}
+
+func TestZipLongestDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 100 {
+			got := ZipLongest(Count(0, 1), FromSlice([]int{7}), -1).Take(3).List()
+			if len(got) != 3 || got[2][1] != -1 {
+				t.Fatalf("ZipLongest = %v", got)
+			}
+		}
+	})
+}
+
+func TestCompressDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 100 {
+			got := Count(0, 1).Compress(Cycle([]bool{true, false})).Take(3).List()
+			if len(got) != 3 || got[2] != 4 {
+				t.Fatalf("Compress = %v", got)
+			}
+		}
+	})
+}
+
+func TestTeeUnusedReaderDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 50 {
+			ts := Tee(Count(0, 1), 2, 0)
+			got := ts[0].Take(3).List()
+			if len(got) != 3 || got[2] != 2 {
+				t.Fatalf("Tee()[0].Take(3) = %v, want [0 1 2]", got)
+			}
+		}
+	})
+}
+
+func TestTeeAllReadersStopEarlyDoesNotLeak(t *testing.T) {
+	assertNoLeaks(t, func() {
+		for range 50 {
+			ts := Tee(Count(0, 1), 3, 0)
+			for _, s := range ts {
+				if got := s.Take(2).List(); len(got) != 2 {
+					t.Fatalf("Take(2) = %v", got)
+				}
+			}
+		}
+	})
+}
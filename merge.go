@@ -0,0 +1,91 @@
+package lazystream
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// MergeSorted lazily merges streams that are each already sorted by cmp into
+// one sorted stream. Only the current head of every input is held in memory.
+// Equal elements keep the order of the streams they came from.
+func MergeSorted[T any](cmp func(T, T) int, streams ...*Stream[T]) *Stream[T] {
+	seqs := make([]iter.Seq[T], len(streams))
+	for i, stream := range streams {
+		seqs[i] = stream._seq
+	}
+	return &Stream[T]{mergeSeqs(cmp, seqs)}
+}
+
+// MergeSortedBy is MergeSorted for streams sorted by an ordered key.
+func MergeSortedBy[T any, K cmp.Ordered](keyFunc func(T) K, streams ...*Stream[T]) *Stream[T] {
+	return MergeSorted(func(a, b T) int {
+		return cmp.Compare(keyFunc(a), keyFunc(b))
+	}, streams...)
+}
+
+func mergeSeqs[T any](cmp func(T, T) int, seqs []iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		h := &mergeHeap[T]{cmp: cmp}
+		defer func() {
+			for _, head := range h.heads {
+				head.stop()
+			}
+		}()
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			item, ok := next()
+			if !ok {
+				stop()
+				continue
+			}
+			h.heads = append(h.heads, &mergeHead[T]{item: item, index: i, next: next, stop: stop})
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			head := h.heads[0]
+			if !yield(head.item) {
+				return
+			}
+			item, ok := head.next()
+			if ok {
+				head.item = item
+				heap.Fix(h, 0)
+			} else {
+				head.stop()
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+type mergeHead[T any] struct {
+	item  T
+	index int
+	next  func() (T, bool)
+	stop  func()
+}
+
+type mergeHeap[T any] struct {
+	heads []*mergeHead[T]
+	cmp   func(T, T) int
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.heads) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	if c := h.cmp(h.heads[i].item, h.heads[j].item); c != 0 {
+		return c < 0
+	}
+	return h.heads[i].index < h.heads[j].index
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.heads = append(h.heads, x.(*mergeHead[T])) }
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return last
+}
@@ -13,6 +13,20 @@ func (s *Stream[T]) Reduce(reducer func(T, T) T, initial T) T {
 	return Reduce(s, reducer, initial)
 }
 
+// ReduceOption reduces the stream using its first element as the seed, or
+// returns None if the stream is empty.
+func (s *Stream[T]) ReduceOption(reducer func(T, T) T) Option[T] {
+	result := None[T]()
+	for item := range s._seq {
+		if result.ok {
+			result.value = reducer(result.value, item)
+		} else {
+			result = Some(item)
+		}
+	}
+	return result
+}
+
 func Chunked[T any](s *Stream[T], predicate func(T) bool) *Stream[[]T] {
 	return &Stream[[]T]{func(yield func([]T) bool) {
 		var chunk []T
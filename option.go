@@ -0,0 +1,53 @@
+package lazystream
+
+// Option holds a value that may be absent.
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+func Some[T any](value T) Option[T] {
+	return Option[T]{value: value, ok: true}
+}
+
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+func (o Option[T]) OrElse(other T) T {
+	if o.ok {
+		return o.value
+	}
+	return other
+}
+
+func (o Option[T]) OrElseGet(supplier func() T) T {
+	if o.ok {
+		return o.value
+	}
+	return supplier()
+}
+
+func (o Option[T]) Map(mapper func(T) T) Option[T] {
+	return MapOption(o, mapper)
+}
+
+// NOTE: Fix when MethodGenerics are supported
+func MapOption[T, R any](o Option[T], mapper func(T) R) Option[R] {
+	if o.ok {
+		return Some(mapper(o.value))
+	}
+	return None[R]()
+}
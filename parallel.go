@@ -0,0 +1,205 @@
+package lazystream
+
+import "sync"
+
+// ParallelMap applies mapper to the elements of s on the given number of
+// worker goroutines and yields the results in input order. At most about
+// 2*workers elements are in flight at any time, so infinite sources are fine.
+// All workers are stopped before the stream returns. If mapper panics, the
+// panic is re-raised on the consumer's goroutine once the workers have stopped.
+func ParallelMap[T, R any](s *Stream[T], workers int, mapper func(T) R) *Stream[R] {
+	if workers < 1 {
+		workers = 1
+	}
+	type job struct {
+		item T
+		out  chan outcome[R]
+	}
+	return &Stream[R]{func(yield func(R) bool) {
+		done := make(chan struct{})
+		jobs := make(chan job)
+		pending := make(chan chan outcome[R], workers)
+		var wg sync.WaitGroup
+		var failure *outcome[R]
+		defer func() {
+			if failure != nil {
+				panic(failure.panicValue)
+			}
+		}()
+		defer wg.Wait()
+		defer close(done)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			defer close(pending)
+			for item := range s._seq {
+				out := make(chan outcome[R], 1)
+				select {
+				case pending <- out:
+				case <-done:
+					return
+				}
+				select {
+				case jobs <- job{item, out}:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range jobs {
+					j.out <- protect(mapper, j.item)
+				}
+			}()
+		}
+
+		for out := range pending {
+			result := <-out
+			if result.panicked {
+				failure = &result
+				return
+			}
+			if !yield(result.value) {
+				return
+			}
+		}
+	}}
+}
+
+// outcome carries either a worker's result or the value it panicked with.
+type outcome[R any] struct {
+	value      R
+	panicked   bool
+	panicValue any
+}
+
+func protect[T, R any](fn func(T) R, item T) (result outcome[R]) {
+	defer func() {
+		if p := recover(); p != nil {
+			result.panicked, result.panicValue = true, p
+		}
+	}()
+	result.value = fn(item)
+	return result
+}
+
+// ParallelMapUnordered applies mapper on the given number of worker goroutines
+// and yields each result as soon as it is ready, regardless of input order.
+// buffer sets the capacity of the result channel between workers and consumer.
+// A panic in mapper is re-raised on the consumer's goroutine once the workers
+// have stopped; the same holds for the other unordered variants.
+func ParallelMapUnordered[T, R any](s *Stream[T], workers, buffer int, mapper func(T) R) *Stream[R] {
+	return parallelUnordered(s, workers, buffer, func(item T, emit func(R) bool) bool {
+		return emit(mapper(item))
+	})
+}
+
+// ParallelFilterUnordered evaluates predicate on the given number of worker
+// goroutines and yields matching elements in completion order.
+func ParallelFilterUnordered[T any](s *Stream[T], workers, buffer int, predicate func(T) bool) *Stream[T] {
+	return parallelUnordered(s, workers, buffer, func(item T, emit func(T) bool) bool {
+		if predicate(item) {
+			return emit(item)
+		}
+		return true
+	})
+}
+
+// ParallelFlatMapUnordered applies mapper on the given number of worker
+// goroutines and yields the elements of each returned slice in completion order.
+func ParallelFlatMapUnordered[T, R any](s *Stream[T], workers, buffer int, mapper func(T) []R) *Stream[R] {
+	return parallelUnordered(s, workers, buffer, func(item T, emit func(R) bool) bool {
+		for _, r := range mapper(item) {
+			if !emit(r) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func parallelUnordered[T, R any](s *Stream[T], workers, buffer int, process func(T, func(R) bool) bool) *Stream[R] {
+	if workers < 1 {
+		workers = 1
+	}
+	if buffer < 0 {
+		buffer = 0
+	}
+	return &Stream[R]{func(yield func(R) bool) {
+		done := make(chan struct{})
+		jobs := make(chan T)
+		results := make(chan outcome[R], buffer)
+		var wg sync.WaitGroup
+		var failure *outcome[R]
+		defer func() {
+			close(done)
+			for range results {
+			}
+			if failure != nil {
+				panic(failure.panicValue)
+			}
+		}()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			for item := range s._seq {
+				select {
+				case jobs <- item:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		emit := func(r R) bool {
+			select {
+			case results <- outcome[R]{value: r}:
+				return true
+			case <-done:
+				return false
+			}
+		}
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() {
+					if p := recover(); p != nil {
+						select {
+						case results <- outcome[R]{panicked: true, panicValue: p}:
+						case <-done:
+						}
+					}
+				}()
+				for item := range jobs {
+					if !process(item, emit) {
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for result := range results {
+			if result.panicked {
+				failure = &result
+				return
+			}
+			if !yield(result.value) {
+				return
+			}
+		}
+	}}
+}
@@ -1,6 +1,10 @@
 package lazystream
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
 
 // | `map(func)/select(func)`
 // | Maps `func` onto elements of sequence
@@ -50,45 +54,198 @@ func FlatMap[T, R any](s *Stream[T], mapper func(T) []R) *Stream[R] {
 // | Groups sequence of `(key, value)` pairs by `key`
 // | transformation |
 
+// GroupByKey collects the values of each key. Keys are emitted in the order
+// they were first seen; use SortByKey for sorted output.
+func GroupByKey[K comparable, V any](s *Stream2[K, V]) *Stream2[K, []V] {
+	return aggregateByKey(s, func(v V) []V { return []V{v} }, func(acc []V, v V) []V {
+		return append(acc, v)
+	})
+}
+
 // | `reduce_by_key(func)`
 // | Reduces list of `(key, value)` pairs using `func`
 // | transformation |
 
+// ReduceByKey folds the values of each key with reducer, seeded by the first
+// value seen for that key. Keys are emitted in first-seen order.
+func ReduceByKey[K comparable, V any](s *Stream2[K, V], reducer func(V, V) V) *Stream2[K, V] {
+	return aggregateByKey(s, Identity[V], reducer)
+}
+
 // | `count_by_key()`
 // | Counts occurrence of each `key` in sequence of `(key, value)` pairs
 // | transformation |
 
+func CountByKey[K comparable, V any](s *Stream2[K, V]) *Stream2[K, int] {
+	return aggregateByKey(s, func(V) int { return 1 }, func(n int, _ V) int { return n + 1 })
+}
+
 // | `count_by_value()`
 // | Counts occurrence of each value in the sequence
 // | transformation |
 
+func CountByValue[T comparable](s *Stream[T]) *Stream2[T, int] {
+	return CountByKey(&Stream2[T, struct{}]{func(yield func(T, struct{}) bool) {
+		for item := range s._seq {
+			if !yield(item, struct{}{}) {
+				return
+			}
+		}
+	}})
+}
+
+// SortByKey buffers s and re-emits it stably sorted by key.
+func SortByKey[K, V any](s *Stream2[K, V], cmp func(K, K) int) *Stream2[K, V] {
+	return &Stream2[K, V]{func(yield func(K, V) bool) {
+		pairs := ToPairStream(s).List()
+		slices.SortStableFunc(pairs, func(a, b Pair[K, V]) int {
+			return cmp(a.Left, b.Left)
+		})
+		for _, pair := range pairs {
+			if !yield(pair.Left, pair.Right) {
+				return
+			}
+		}
+	}}
+}
+
+func aggregateByKey[K comparable, V, A any](s *Stream2[K, V], seed func(V) A, accumulate func(A, V) A) *Stream2[K, A] {
+	return &Stream2[K, A]{func(yield func(K, A) bool) {
+		var keys []K
+		acc := make(map[K]A)
+		for k, v := range s._seq {
+			if a, ok := acc[k]; ok {
+				acc[k] = accumulate(a, v)
+			} else {
+				keys = append(keys, k)
+				acc[k] = seed(v)
+			}
+		}
+		for _, k := range keys {
+			if !yield(k, acc[k]) {
+				return
+			}
+		}
+	}}
+}
+
 // | `union(other)`
 // | Union of unique elements in sequence and `other`
 // | transformation |
 
+func Union[T comparable](s *Stream[T], other *Stream[T]) *Stream[T] {
+	return UnionBy(s, other, Identity[T])
+}
+
+func UnionBy[T any, K comparable](s *Stream[T], other *Stream[T], keyFunc func(T) K) *Stream[T] {
+	return DistinctBy(s.Chain(other), keyFunc)
+}
+
 // | `intersection(other)`
 // | Intersection of unique elements in sequence and `other`
 // | transformation |
 
+func Intersection[T comparable](s *Stream[T], other *Stream[T]) *Stream[T] {
+	return IntersectionBy(s, other, Identity[T])
+}
+
+func IntersectionBy[T any, K comparable](s *Stream[T], other *Stream[T], keyFunc func(T) K) *Stream[T] {
+	return &Stream[T]{func(yield func(T) bool) {
+		right := keySet(other, keyFunc)
+		for item := range DistinctBy(s, keyFunc)._seq {
+			if right[keyFunc(item)] {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}}
+}
+
 // | `difference(other)`
 // | New sequence with unique elements present in sequence but not in `other`
 // | transformation |
 
+func Difference[T comparable](s *Stream[T], other *Stream[T]) *Stream[T] {
+	return DifferenceBy(s, other, Identity[T])
+}
+
+func DifferenceBy[T any, K comparable](s *Stream[T], other *Stream[T], keyFunc func(T) K) *Stream[T] {
+	return &Stream[T]{func(yield func(T) bool) {
+		right := keySet(other, keyFunc)
+		for item := range DistinctBy(s, keyFunc)._seq {
+			if !right[keyFunc(item)] {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}}
+}
+
 // | `symmetric_difference(other)`
 // | New sequence with unique elements present in sequence or `other`, but not both
 // | transformation |
 
+func SymmetricDifference[T comparable](s *Stream[T], other *Stream[T]) *Stream[T] {
+	return SymmetricDifferenceBy(s, other, Identity[T])
+}
+
+// SymmetricDifferenceBy yields the unmatched elements of s followed by the
+// unmatched elements of other.
+func SymmetricDifferenceBy[T any, K comparable](s *Stream[T], other *Stream[T], keyFunc func(T) K) *Stream[T] {
+	return &Stream[T]{func(yield func(T) bool) {
+		right := DistinctBy(other, keyFunc).List()
+		rightKeys := make(map[K]bool, len(right))
+		for _, item := range right {
+			rightKeys[keyFunc(item)] = true
+		}
+		leftKeys := make(map[K]bool)
+		for item := range s._seq {
+			key := keyFunc(item)
+			if leftKeys[key] {
+				continue
+			}
+			leftKeys[key] = true
+			if !rightKeys[key] {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+		for _, item := range right {
+			if !leftKeys[keyFunc(item)] {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+func keySet[T any, K comparable](s *Stream[T], keyFunc func(T) K) map[K]bool {
+	set := make(map[K]bool)
+	for item := range s._seq {
+		set[keyFunc(item)] = true
+	}
+	return set
+}
+
 // | `distinct()`
 // | Returns distinct elements of sequence. Elements must be hashable
 // | transformation |
 
+func Distinct[T comparable](s *Stream[T]) *Stream[T] {
+	return DistinctBy(s, Identity[T])
+}
+
 // | `distinct_by(func)`
 // | Returns distinct elements of sequence using `func` as a key
 // | transformation |
 
 func DistinctBy[T any, R comparable](s *Stream[T], keyFunc func(T) R) *Stream[T] {
-	seen := make(map[R]bool)
 	return &Stream[T]{func(yield func(T) bool) {
+		seen := make(map[R]bool)
 		for item := range s._seq {
 			key := keyFunc(item)
 			if !seen[key] {
@@ -205,6 +362,121 @@ func (s *Stream[T]) Take(n int) *Stream[T] {
 // | Returns join of sequence with `other` as specified by `join_type`. Must be a sequence of `(key, value)` pairs
 // | transformation |
 
+type JoinType int
+
+const (
+	JoinInner JoinType = iota
+	JoinLeft
+	JoinRight
+	JoinOuter
+)
+
+// Join hash-joins left and right on their keys. The right side is
+// materialized; the left side is streamed. Matches are emitted in left order,
+// and for JoinRight and JoinOuter the unmatched right entries follow in their
+// original order. The side that is missing from a match is None.
+func Join[K comparable, V, W any](left *Stream2[K, V], right *Stream2[K, W], joinType JoinType) *Stream2[K, Pair[Option[V], Option[W]]] {
+	type Row = Pair[Option[V], Option[W]]
+	return &Stream2[K, Row]{func(yield func(K, Row) bool) {
+		index := make(map[K][]W)
+		var order []K
+		for k, w := range right._seq {
+			if _, ok := index[k]; !ok {
+				order = append(order, k)
+			}
+			index[k] = append(index[k], w)
+		}
+		matched := make(map[K]bool)
+		for k, v := range left._seq {
+			ws, ok := index[k]
+			if !ok {
+				if joinType == JoinLeft || joinType == JoinOuter {
+					if !yield(k, Row{Some(v), None[W]()}) {
+						return
+					}
+				}
+				continue
+			}
+			matched[k] = true
+			for _, w := range ws {
+				if !yield(k, Row{Some(v), Some(w)}) {
+					return
+				}
+			}
+		}
+		if joinType == JoinRight || joinType == JoinOuter {
+			for _, k := range order {
+				if matched[k] {
+					continue
+				}
+				for _, w := range index[k] {
+					if !yield(k, Row{None[V](), Some(w)}) {
+						return
+					}
+				}
+			}
+		}
+	}}
+}
+
+func InnerJoin[K comparable, V, W any](left *Stream2[K, V], right *Stream2[K, W]) *Stream2[K, Pair[V, W]] {
+	return MapValues(Join(left, right, JoinInner), func(row Pair[Option[V], Option[W]]) Pair[V, W] {
+		return Pair[V, W]{row.Left.value, row.Right.value}
+	})
+}
+
+func LeftJoin[K comparable, V, W any](left *Stream2[K, V], right *Stream2[K, W]) *Stream2[K, Pair[V, Option[W]]] {
+	return MapValues(Join(left, right, JoinLeft), func(row Pair[Option[V], Option[W]]) Pair[V, Option[W]] {
+		return Pair[V, Option[W]]{row.Left.value, row.Right}
+	})
+}
+
+func RightJoin[K comparable, V, W any](left *Stream2[K, V], right *Stream2[K, W]) *Stream2[K, Pair[Option[V], W]] {
+	return MapValues(Join(left, right, JoinRight), func(row Pair[Option[V], Option[W]]) Pair[Option[V], W] {
+		return Pair[Option[V], W]{row.Left, row.Right.value}
+	})
+}
+
+func OuterJoin[K comparable, V, W any](left *Stream2[K, V], right *Stream2[K, W]) *Stream2[K, Pair[Option[V], Option[W]]] {
+	return Join(left, right, JoinOuter)
+}
+
+// SortMergeJoin inner-joins two streams that are both sorted by key according
+// to cmp. Only the current run of equal right keys is held in memory.
+func SortMergeJoin[K, V, W any](left *Stream2[K, V], right *Stream2[K, W], cmp func(K, K) int) *Stream2[K, Pair[V, W]] {
+	return &Stream2[K, Pair[V, W]]{func(yield func(K, Pair[V, W]) bool) {
+		rightNext, rightStop := iter.Pull2(right._seq)
+		defer rightStop()
+		rk, rw, rok := rightNext()
+		var runKey K
+		var run []W
+		haveRun := false
+		for lk, lv := range left._seq {
+			if !haveRun || cmp(lk, runKey) != 0 {
+				for rok && cmp(rk, lk) < 0 {
+					rk, rw, rok = rightNext()
+				}
+				haveRun = false
+				run = run[:0]
+				for rok && cmp(rk, lk) == 0 {
+					run = append(run, rw)
+					rk, rw, rok = rightNext()
+				}
+				if len(run) > 0 {
+					runKey, haveRun = lk, true
+				} else if !rok {
+					return
+				}
+			}
+			for _, w := range run {
+				if !yield(lk, Pair[V, W]{lv, w}) {
+					return
+				}
+			}
+		}
+	}}
+}
+
 // | `partition(func)`
 // | Partitions the sequence into elements that satisfy `func(element)` and those that don't
 // | transformation |
@@ -232,6 +504,12 @@ func (s *Stream[T]) Take(n int) *Stream[T] {
 // | `head_option(no_wrap=None)`
 // | Returns first element in sequence or `None` if its empty (if `no_wrap=True`, the result will never be wrapped with `Sequence`)
 // | action         |
+func (s *Stream[T]) HeadOption() Option[T] {
+	for item := range s._seq {
+		return Some(item)
+	}
+	return None[T]()
+}
 
 // | `last(no_wrap=None)`
 // | Returns last element in sequence (if `no_wrap=True`, the result will never be wrapped with `Sequence`)
@@ -240,6 +518,13 @@ func (s *Stream[T]) Take(n int) *Stream[T] {
 // | `last_option(no_wrap=None)`
 // | Returns last element in sequence or `None` if its empty (if `no_wrap=True`, the result will never be wrapped with `Sequence`)
 // | action         |
+func (s *Stream[T]) LastOption() Option[T] {
+	last := None[T]()
+	for item := range s._seq {
+		last = Some(item)
+	}
+	return last
+}
 
 // | `len()` / `size()`
 // | Returns length of sequence
@@ -302,6 +587,13 @@ func (s *Stream[T]) Find(predicate func(T) bool) (T, bool) {
 	return zero, false
 }
 
+func (s *Stream[T]) FindOption(predicate func(T) bool) Option[T] {
+	if item, ok := s.Find(predicate); ok {
+		return Some(item)
+	}
+	return None[T]()
+}
+
 // | `any()`
 // | Returns `True` if any element in sequence is truthy
 // | action         |
@@ -403,8 +695,8 @@ func (s *Stream[T]) Join(separator string) string {
 // | Forces evaluation of sequence immediately and caches the result
 // | action         |
 func (s *Stream[T]) Cache() *Stream[T] {
-	cache := s.List()
-	return FromSlice(cache)
+	// NOTE: Unlike pyfunctional, elements are cached lazily as they are first consumed.
+	return s.CacheWith(CacheOptions{})
 }
 
 // | `for_each(func)`
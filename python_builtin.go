@@ -2,6 +2,7 @@ package lazystream
 
 import (
 	"cmp"
+	"iter"
 	"slices"
 )
 
@@ -83,34 +84,36 @@ func Map[T, R any](s *Stream[T], mapper func(T) R) *Stream[R] {
 
 func (s *Stream[T]) Max(comparator func(T, T) int) T {
 	// max
-	var max T
-	first := true
-	for item := range s._seq {
-		if first {
-			max = item
-			first = false
-		} else if comparator(item, max) > 0 {
-			max = item
-		}
-	}
+	max, _ := s.MaxOption(comparator).Get()
 	return max
 }
 
+func (s *Stream[T]) MaxOption(comparator func(T, T) int) Option[T] {
+	// max
+	return s.ReduceOption(func(max, item T) T {
+		if comparator(item, max) > 0 {
+			return item
+		}
+		return max
+	})
+}
+
 func (s *Stream[T]) Min(comparator func(T, T) int) T {
 	// min
-	var min T
-	first := true
-	for item := range s._seq {
-		if first {
-			min = item
-			first = false
-		} else if comparator(item, min) < 0 {
-			min = item
-		}
-	}
+	min, _ := s.MinOption(comparator).Get()
 	return min
 }
 
+func (s *Stream[T]) MinOption(comparator func(T, T) int) Option[T] {
+	// min
+	return s.ReduceOption(func(min, item T) T {
+		if comparator(item, min) < 0 {
+			return item
+		}
+		return min
+	})
+}
+
 func Range(start, end, step int) *Stream[int] {
 	// range
 	return &Stream[int]{func(yield func(int) bool) {
@@ -193,13 +196,18 @@ func Sort[T cmp.Ordered](s *Stream[T]) *Stream[T] {
 
 func Zip[K, V any](s1 *Stream[K], s2 *Stream[V]) *Stream2[K, V] {
 	// zip
-	leftChan := s1.ToChannel()
-	rightChan := s2.ToChannel()
 	return &Stream2[K, V]{func(yield func(K, V) bool) {
+		leftNext, leftStop := iter.Pull(s1._seq)
+		defer leftStop()
+		rightNext, rightStop := iter.Pull(s2._seq)
+		defer rightStop()
 		for {
-			left, leftOpen := <-leftChan
-			right, rightOpen := <-rightChan
-			if !leftOpen || !rightOpen {
+			left, leftOk := leftNext()
+			if !leftOk {
+				return
+			}
+			right, rightOk := rightNext()
+			if !rightOk {
 				return
 			}
 			if !yield(left, right) {
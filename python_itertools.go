@@ -1,6 +1,11 @@
 package lazystream
 
-import "fmt"
+import (
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+)
 
 ///////////////////////////////////////////////////////////////////////////////
 // region: Infinite iterators:
@@ -142,24 +147,66 @@ func (s *Stream[T]) DropWhile(predicate func(T) bool) *Stream[T] {
 	}}
 }
 
-func GroupBy[T any](s *Stream[T], keyFunc func(T) T) *Stream[[]T] {
+// GroupBy groups consecutive elements that share the same key, like Python's
+// itertools.groupby. Sort the input by key first to get one group per key.
+func GroupBy[T any, K comparable](s *Stream[T], keyFunc func(T) K) *Stream2[K, []T] {
 	// groupby(iterable[, keyfunc]) --> sub-iterators grouped by value of keyfunc(v)
-	return &Stream[[]T]{func(yield func([]T) bool) {
-		var current string
-		acc := []T{}
-		if keyFunc == nil {
-			keyFunc = func(x T) T { return x }
-		}
+	return &Stream2[K, []T]{func(yield func(K, []T) bool) {
+		var current K
+		var acc []T
 		for item := range s._seq {
-			key := fmt.Sprintf("%#v", keyFunc(item))
-			if key != current {
-				if len(acc) > 0 {
-					yield(acc)
+			key := keyFunc(item)
+			if len(acc) > 0 && key != current {
+				if !yield(current, acc) {
+					return
 				}
-				acc = []T{item}
-				current = key
-			} else {
-				acc = append(acc, item)
+				acc = nil
+			}
+			current = key
+			acc = append(acc, item)
+		}
+		if len(acc) > 0 {
+			yield(current, acc)
+		}
+	}}
+}
+
+// GroupByLazy is like GroupBy but yields each group as a sub-stream that pulls
+// directly from s, so large groups are never buffered. A sub-stream is only
+// valid until the outer stream advances to the next group.
+func GroupByLazy[T any, K comparable](s *Stream[T], keyFunc func(T) K) *Stream2[K, *Stream[T]] {
+	return &Stream2[K, *Stream[T]]{func(yield func(K, *Stream[T]) bool) {
+		next, stop := iter.Pull(s._seq)
+		defer stop()
+		var key K
+		item, ok := next()
+		advance := func() {
+			if item, ok = next(); ok {
+				key = keyFunc(item)
+			}
+		}
+		if ok {
+			key = keyFunc(item)
+		}
+		// group is shared by every sub-stream so a stale one can tell that the
+		// outer stream has moved on.
+		group := 0
+		for ; ok; group++ {
+			groupKey, id := key, group
+			sub := &Stream[T]{func(yield func(T) bool) {
+				for ok && group == id && key == groupKey {
+					more := yield(item)
+					advance()
+					if !more {
+						return
+					}
+				}
+			}}
+			if !yield(groupKey, sub) {
+				return
+			}
+			for ok && key == groupKey {
+				advance()
 			}
 		}
 	}}
@@ -198,23 +245,180 @@ func (s *Stream[T]) ISlice(start int, stop int) *Stream[T] {
 
 func PairWise[T any](s *Stream[T]) *Stream[Pair[T, T]] {
 	// pairwise(s) --> (s[0],s[1]), (s[1],s[2]), (s[2], s[3]), ...
-	return &Stream[Pair[T, T]]{func(yield func(Pair[T, T]) bool) {
-		for item := range Batched(s, 2)._seq {
-			if len(item) == 2 {
-				if !yield(Pair[T, T]{Left: item[0], Right: item[1]}) {
-					return
-				}
-			}
-			var zero T
-			if !yield(Pair[T, T]{Left: item[0], Right: zero}) {
+	return Map(Sliding(s, 2, 1, WindowOptions{}), func(window []T) Pair[T, T] {
+		return Pair[T, T]{Left: window[0], Right: window[1]}
+	})
+}
+
+func StarMap[K, V, R any](s *Stream2[K, V], fn func(K, V) R) *Stream[R] {
+	// starmap(fun, seq) --> fun(*seq[0]), fun(*seq[1]), ...
+	return &Stream[R]{func(yield func(R) bool) {
+		for k, v := range s._seq {
+			if !yield(fn(k, v)) {
 				return
 			}
 		}
 	}}
 }
 
-// starmap(fun, seq) --> fun(*seq[0]), fun(*seq[1]), ...
-// tee(it, n=2) --> (it1, it2 , ... itn) splits one iterator into n
+func StarMap2[A, B, R any](s *Stream[Pair[A, B]], fn func(A, B) R) *Stream[R] {
+	// starmap(fun, seq) --> fun(*seq[0]), fun(*seq[1]), ...
+	return Map(s, func(pair Pair[A, B]) R {
+		return fn(pair.Splat())
+	})
+}
+
+func StarMap3[A, B, C, R any](s *Stream[Triplet[A, B, C]], fn func(A, B, C) R) *Stream[R] {
+	// starmap(fun, seq) --> fun(*seq[0]), fun(*seq[1]), ...
+	return Map(s, func(triplet Triplet[A, B, C]) R {
+		return fn(triplet.Splat())
+	})
+}
+
+// Tee splits s into n streams that each see every element of s. The source is
+// iterated once; elements are buffered only until the slowest reader has
+// consumed them. The returned streams may be consumed one after another or
+// concurrently from different goroutines, but each can only be iterated once.
+//
+// If limit is positive, a reader that gets more than limit elements ahead of
+// the slowest reader blocks until it catches up. A limit therefore requires the
+// readers to be consumed concurrently.
+func Tee[T any](s *Stream[T], n, limit int) []*Stream[T] {
+	// tee(it, n=2) --> (it1, it2 , ... itn) splits one iterator into n
+	if n < 1 {
+		panic("tee count must be positive")
+	}
+	t := &tee[T]{
+		seq:   s._seq,
+		pull:  &pulled[T]{},
+		pos:   make([]int, n),
+		state: make([]int, n),
+		limit: limit,
+	}
+	t.cond = sync.NewCond(&t.mu)
+	// A reader that is never iterated keeps the source suspended; release it
+	// once all of the returned streams have been dropped.
+	runtime.AddCleanup(t, func(pull *pulled[T]) {
+		if pull.stop != nil {
+			pull.stop()
+		}
+	}, t.pull)
+	streams := make([]*Stream[T], n)
+	for i := range streams {
+		streams[i] = &Stream[T]{func(yield func(T) bool) { t.read(i, yield) }}
+	}
+	return streams
+}
+
+const (
+	teeIdle = iota
+	teeRunning
+	teeFinished
+)
+
+type tee[T any] struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	seq     iter.Seq[T]
+	pull    *pulled[T]
+	pulling bool
+	done    bool
+	buf     []T
+	base    int
+	pos     []int
+	state   []int
+	limit   int
+}
+
+func (t *tee[T]) read(i int, yield func(T) bool) {
+	t.mu.Lock()
+	if t.state[i] != teeIdle {
+		t.mu.Unlock()
+		return
+	}
+	t.state[i] = teeRunning
+	// The lock is released while the source and the consumer run, so a panic
+	// from either must re-take it before the reader is retired.
+	locked, pulling := true, false
+	defer func() {
+		if !locked {
+			t.mu.Lock()
+		}
+		if pulling {
+			// The source panicked; nobody can pull from it again.
+			t.pulling = false
+			t.done = true
+			t.cond.Broadcast()
+		}
+		t.finish(i)
+		t.mu.Unlock()
+	}()
+	if t.pull.next == nil {
+		t.pull.next, t.pull.stop = iter.Pull(t.seq)
+	}
+	for {
+		for t.pos[i]-t.base == len(t.buf) {
+			if t.done {
+				return
+			}
+			if t.pulling || (t.limit > 0 && len(t.buf) >= t.limit) {
+				t.cond.Wait()
+				continue
+			}
+			t.pulling, pulling = true, true
+			t.mu.Unlock()
+			locked = false
+			item, ok := t.pull.next()
+			t.mu.Lock()
+			locked = true
+			t.pulling, pulling = false, false
+			if ok {
+				t.buf = append(t.buf, item)
+			} else {
+				t.done = true
+			}
+			t.cond.Broadcast()
+		}
+		item := t.buf[t.pos[i]-t.base]
+		t.pos[i]++
+		t.trim()
+		t.mu.Unlock()
+		locked = false
+		more := yield(item)
+		t.mu.Lock()
+		locked = true
+		if !more {
+			return
+		}
+	}
+}
+
+func (t *tee[T]) finish(i int) {
+	t.state[i] = teeFinished
+	t.trim()
+	if !slices.ContainsFunc(t.state, func(state int) bool { return state != teeFinished }) {
+		t.pull.stop()
+	}
+}
+
+// trim drops buffered elements that every unfinished reader has consumed.
+func (t *tee[T]) trim() {
+	low := -1
+	for i, pos := range t.pos {
+		if t.state[i] != teeFinished && (low < 0 || pos < low) {
+			low = pos
+		}
+	}
+	if low < 0 {
+		low = t.base + len(t.buf)
+	}
+	if k := low - t.base; k > 0 {
+		clear(t.buf[:k])
+		t.buf = t.buf[k:]
+		t.base = low
+		t.cond.Broadcast()
+	}
+}
 
 // TakeWhile returns a new Stream that yields items from the original Stream
 // as long as the provided predicate function returns true. Once the predicate
@@ -258,11 +462,13 @@ func (s *Stream[T]) TakeWhile(predicate func(T) bool) *Stream[T] {
 func ZipLongest[T any](s *Stream[T], other *Stream[T], fillValue T) *Stream[[]T] {
 	// zip_longest(p, q, ...) --> (p[0], q[0]), (p[1], q[1]), ...
 	return &Stream[[]T]{func(yield func([]T) bool) {
-		mainChan := s.ToChannel()
-		otherChan := other.ToChannel()
+		mainNext, mainStop := iter.Pull(s._seq)
+		defer mainStop()
+		otherNext, otherStop := iter.Pull(other._seq)
+		defer otherStop()
 		for {
-			mainItem, mainOk := <-mainChan
-			otherItem, otherOk := <-otherChan
+			mainItem, mainOk := mainNext()
+			otherItem, otherOk := otherNext()
 			if !mainOk && !otherOk {
 				return
 			}
@@ -286,10 +492,200 @@ func ZipLongest[T any](s *Stream[T], other *Stream[T], fillValue T) *Stream[[]T]
 ///////////////////////////////////////////////////////////////////////////////
 // region: Combinatoric generators:
 ///////////////////////////////////////////////////////////////////////////////
-// product(p, q, ... [repeat=1]) --> cartesian product
-// permutations(p[, r])
-// combinations(p, r)
-// combinations_with_replacement(p, r)
+
+// Product yields the cartesian product of pools in lexicographic order. Each
+// pool is repeated repeat times, so Product(2, p) is Product(1, p, p).
+func Product[T any](repeat int, pools ...[]T) *Stream[[]T] {
+	// product(p, q, ... [repeat=1]) --> cartesian product
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		var all [][]T
+		for range repeat {
+			all = append(all, pools...)
+		}
+		for _, pool := range all {
+			if len(pool) == 0 {
+				return
+			}
+		}
+		indices := make([]int, len(all))
+		for {
+			tuple := make([]T, len(all))
+			for i, j := range indices {
+				tuple[i] = all[i][j]
+			}
+			if !yield(tuple) {
+				return
+			}
+			i := len(indices) - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] < len(all[i]) {
+					break
+				}
+				indices[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}}
+}
+
+// ProductOf is like Product but buffers each input stream once per
+// iteration.
+func ProductOf[T any](repeat int, streams ...*Stream[T]) *Stream[[]T] {
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		pools := make([][]T, len(streams))
+		for i, stream := range streams {
+			pools[i] = stream.List()
+		}
+		Product(repeat, pools...)._seq(yield)
+	}}
+}
+
+// Permutations yields successive r-length permutations of pool in
+// lexicographic index order. A negative r means len(pool).
+func Permutations[T any](pool []T, r int) *Stream[[]T] {
+	// permutations(p[, r])
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		n := len(pool)
+		if r < 0 {
+			r = n
+		}
+		if r > n {
+			return
+		}
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		cycles := make([]int, r)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+		emit := func() bool {
+			tuple := make([]T, r)
+			for i := range tuple {
+				tuple[i] = pool[indices[i]]
+			}
+			return yield(tuple)
+		}
+		if !emit() {
+			return
+		}
+		for n > 0 {
+			i := r - 1
+			for ; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] == 0 {
+					first := indices[i]
+					copy(indices[i:], indices[i+1:])
+					indices[n-1] = first
+					cycles[i] = n - i
+				} else {
+					j := cycles[i]
+					indices[i], indices[n-j] = indices[n-j], indices[i]
+					if !emit() {
+						return
+					}
+					break
+				}
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}}
+}
+
+// Combinations yields r-length subsequences of pool in lexicographic index
+// order, without repeated elements.
+func Combinations[T any](pool []T, r int) *Stream[[]T] {
+	// combinations(p, r)
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		n := len(pool)
+		if r < 0 || r > n {
+			return
+		}
+		indices := make([]int, r)
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			tuple := make([]T, r)
+			for i, j := range indices {
+				tuple[i] = pool[j]
+			}
+			if !yield(tuple) {
+				return
+			}
+			i := r - 1
+			for ; i >= 0 && indices[i] == i+n-r; i-- {
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < r; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}}
+}
+
+// CombinationsWithReplacement yields r-length subsequences of pool in
+// lexicographic index order, allowing elements to repeat.
+func CombinationsWithReplacement[T any](pool []T, r int) *Stream[[]T] {
+	// combinations_with_replacement(p, r)
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		n := len(pool)
+		if r < 0 || (n == 0 && r > 0) {
+			return
+		}
+		indices := make([]int, r)
+		for {
+			tuple := make([]T, r)
+			for i, j := range indices {
+				tuple[i] = pool[j]
+			}
+			if !yield(tuple) {
+				return
+			}
+			i := r - 1
+			for ; i >= 0 && indices[i] == n-1; i-- {
+			}
+			if i < 0 {
+				return
+			}
+			next := indices[i] + 1
+			for j := i; j < r; j++ {
+				indices[j] = next
+			}
+		}
+	}}
+}
+
+// PermutationsOf is like Permutations but buffers s once per iteration.
+func PermutationsOf[T any](s *Stream[T], r int) *Stream[[]T] {
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		Permutations(s.List(), r)._seq(yield)
+	}}
+}
+
+// CombinationsOf is like Combinations but buffers s once per iteration.
+func CombinationsOf[T any](s *Stream[T], r int) *Stream[[]T] {
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		Combinations(s.List(), r)._seq(yield)
+	}}
+}
+
+// CombinationsWithReplacementOf is like CombinationsWithReplacement but buffers s once per iteration.
+func CombinationsWithReplacementOf[T any](s *Stream[T], r int) *Stream[[]T] {
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		CombinationsWithReplacement(s.List(), r)._seq(yield)
+	}}
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // endregion: Combinatoric generators:
 ///////////////////////////////////////////////////////////////////////////////
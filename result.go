@@ -0,0 +1,172 @@
+package lazystream
+
+// Result carries either a value or the error that prevented producing it.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+func Ok[T any](value T) Result[T] {
+	return Result[T]{Value: value}
+}
+
+func Err[T any](err error) Result[T] {
+	return Result[T]{Err: err}
+}
+
+func (r Result[T]) IsOk() bool {
+	return r.Err == nil
+}
+
+func (r Result[T]) Unwrap() (T, error) {
+	return r.Value, r.Err
+}
+
+// TryMap maps each element with a fallible mapper. Failures are carried
+// downstream as error Results instead of stopping the stream.
+func TryMap[T, R any](s *Stream[T], mapper func(T) (R, error)) *Stream[Result[R]] {
+	return &Stream[Result[R]]{func(yield func(Result[R]) bool) {
+		for item := range s._seq {
+			value, err := mapper(item)
+			if !yield(Result[R]{Value: value, Err: err}) {
+				return
+			}
+		}
+	}}
+}
+
+// TryFilter keeps the elements for which predicate returns true. Elements whose
+// predicate fails are emitted as error Results.
+func TryFilter[T any](s *Stream[T], predicate func(T) (bool, error)) *Stream[Result[T]] {
+	return &Stream[Result[T]]{func(yield func(Result[T]) bool) {
+		for item := range s._seq {
+			keep, err := predicate(item)
+			if err != nil {
+				if !yield(Err[T](err)) {
+					return
+				}
+				continue
+			}
+			if keep {
+				if !yield(Ok(item)) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// TryFlatMap flattens the slices returned by a fallible mapper. A failing
+// element produces a single error Result.
+func TryFlatMap[T, R any](s *Stream[T], mapper func(T) ([]R, error)) *Stream[Result[R]] {
+	return &Stream[Result[R]]{func(yield func(Result[R]) bool) {
+		for item := range s._seq {
+			values, err := mapper(item)
+			if err != nil {
+				if !yield(Err[R](err)) {
+					return
+				}
+				continue
+			}
+			for _, value := range values {
+				if !yield(Ok(value)) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// AndThen applies a fallible mapper to successful Results and passes errors
+// through untouched, so Try stages can be chained.
+func AndThen[T, R any](s *Stream[Result[T]], mapper func(T) (R, error)) *Stream[Result[R]] {
+	return &Stream[Result[R]]{func(yield func(Result[R]) bool) {
+		for item := range s._seq {
+			var result Result[R]
+			if item.Err != nil {
+				result.Err = item.Err
+			} else {
+				result.Value, result.Err = mapper(item.Value)
+			}
+			if !yield(result) {
+				return
+			}
+		}
+	}}
+}
+
+// ErrorPolicy decides what happens to an error Result. It returns true to drop
+// the error and keep going, or false to stop the stream.
+type ErrorPolicy func(error) bool
+
+// FailFast stops the stream at the first error and stores it in errp, so the
+// caller can tell a failed stream from one that ran to completion.
+func FailFast(errp *error) ErrorPolicy {
+	return func(err error) bool {
+		*errp = err
+		return false
+	}
+}
+
+// SkipErrors silently drops errors.
+func SkipErrors() ErrorPolicy {
+	return func(error) bool { return true }
+}
+
+// CollectErrors drops errors from the stream and appends them to errs.
+func CollectErrors(errs *[]error) ErrorPolicy {
+	return func(err error) bool {
+		*errs = append(*errs, err)
+		return true
+	}
+}
+
+// RouteErrors drops errors from the stream and sends them to ch. The send
+// blocks, so ch must be drained while the stream is consumed.
+func RouteErrors(ch chan<- error) ErrorPolicy {
+	return func(err error) bool {
+		ch <- err
+		return true
+	}
+}
+
+// Values unwraps a stream of Results, handing every error to policy.
+func Values[T any](s *Stream[Result[T]], policy ErrorPolicy) *Stream[T] {
+	return &Stream[T]{func(yield func(T) bool) {
+		for item := range s._seq {
+			if item.Err != nil {
+				if !policy(item.Err) {
+					return
+				}
+				continue
+			}
+			if !yield(item.Value) {
+				return
+			}
+		}
+	}}
+}
+
+// NOTE: Fix when MethodGenerics are supported
+func ListErr[T any](s *Stream[Result[T]]) ([]T, error) {
+	var list []T
+	err := ForEachErr(s, func(item T) error {
+		list = append(list, item)
+		return nil
+	})
+	return list, err
+}
+
+// ForEachErr executes action on each successful value and returns the first
+// error, whether it came from the stream or from action.
+func ForEachErr[T any](s *Stream[Result[T]], action func(T) error) error {
+	for item := range s._seq {
+		if item.Err != nil {
+			return item.Err
+		}
+		if err := action(item.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
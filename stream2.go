@@ -0,0 +1,135 @@
+package lazystream
+
+import "iter"
+
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) *Stream2[K, V] {
+	return &Stream2[K, V]{seq}
+}
+
+// FromMap streams the entries of m in Go's unspecified map order.
+func FromMap[K comparable, V any](m map[K]V) *Stream2[K, V] {
+	return &Stream2[K, V]{func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}}
+}
+
+func (s *Stream2[K, V]) Iter() iter.Seq2[K, V] {
+	return s._seq
+}
+
+func (s *Stream2[K, V]) Filter(predicate func(K, V) bool) *Stream2[K, V] {
+	return &Stream2[K, V]{func(yield func(K, V) bool) {
+		for k, v := range s._seq {
+			if predicate(k, v) {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// NOTE: Fix when MethodGenerics are supported
+func MapKeys[K, V, R any](s *Stream2[K, V], mapper func(K) R) *Stream2[R, V] {
+	return &Stream2[R, V]{func(yield func(R, V) bool) {
+		for k, v := range s._seq {
+			if !yield(mapper(k), v) {
+				return
+			}
+		}
+	}}
+}
+
+// NOTE: Fix when MethodGenerics are supported
+func MapValues[K, V, R any](s *Stream2[K, V], mapper func(V) R) *Stream2[K, R] {
+	return &Stream2[K, R]{func(yield func(K, R) bool) {
+		for k, v := range s._seq {
+			if !yield(k, mapper(v)) {
+				return
+			}
+		}
+	}}
+}
+
+func (s *Stream2[K, V]) Keys() *Stream[K] {
+	return &Stream[K]{func(yield func(K) bool) {
+		for k := range s._seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}}
+}
+
+func (s *Stream2[K, V]) Values() *Stream[V] {
+	return &Stream[V]{func(yield func(V) bool) {
+		for _, v := range s._seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+func (s *Stream2[K, V]) Take(n int) *Stream2[K, V] {
+	return &Stream2[K, V]{func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for k, v := range s._seq {
+			if !yield(k, v) {
+				return
+			}
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}}
+}
+
+func (s *Stream2[K, V]) Drop(n int) *Stream2[K, V] {
+	return &Stream2[K, V]{func(yield func(K, V) bool) {
+		i := 0
+		for k, v := range s._seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}}
+}
+
+func (s *Stream2[K, V]) Swap() *Stream2[V, K] {
+	return &Stream2[V, K]{func(yield func(V, K) bool) {
+		for k, v := range s._seq {
+			if !yield(v, k) {
+				return
+			}
+		}
+	}}
+}
+
+func (s *Stream2[K, V]) ForEach(action func(K, V)) {
+	for k, v := range s._seq {
+		action(k, v)
+	}
+}
+
+// ToMap collects the stream into a map. Later values overwrite earlier ones
+// with the same key.
+func ToMap[K comparable, V any](s *Stream2[K, V]) map[K]V {
+	m := make(map[K]V)
+	for k, v := range s._seq {
+		m[k] = v
+	}
+	return m
+}
@@ -1,5 +1,7 @@
 package lazystream
 
+import "context"
+
 func (s *Stream[T]) ToChannel() chan T {
 	ch := make(chan T)
 	go func() {
@@ -10,3 +12,20 @@ func (s *Stream[T]) ToChannel() chan T {
 	}()
 	return ch
 }
+
+// ToChannelCtx is like ToChannel but the producing goroutine gives up and
+// closes the channel once ctx is done, even if nobody is receiving.
+func (s *Stream[T]) ToChannelCtx(ctx context.Context) chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for item := range s.WithContext(ctx)._seq {
+			select {
+			case ch <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,236 @@
+package lazystream
+
+import (
+	"slices"
+	"time"
+)
+
+// WindowOptions configures count-based windows.
+type WindowOptions struct {
+	// Partial also emits the shorter windows that start near the end of the
+	// stream, instead of dropping them.
+	Partial bool
+}
+
+// Sliding yields windows of size consecutive elements, starting a new window
+// every step elements. Windows overlap when step < size and skip elements when
+// step > size. Only the last size elements are kept in a ring buffer; each
+// window is yielded as a fresh slice.
+func Sliding[T any](s *Stream[T], size, step int, opts WindowOptions) *Stream[[]T] {
+	if size < 1 || step < 1 {
+		panic("window size and step must be positive")
+	}
+	return &Stream[[]T]{func(yield func([]T) bool) {
+		ring := make([]T, size)
+		window := func(start, end int) []T {
+			out := make([]T, 0, end-start)
+			for j := start; j < end; j++ {
+				out = append(out, ring[j%size])
+			}
+			return out
+		}
+		n := 0
+		for item := range s._seq {
+			ring[n%size] = item
+			n++
+			if start := n - size; start >= 0 && start%step == 0 {
+				if !yield(window(start, n)) {
+					return
+				}
+			}
+		}
+		if !opts.Partial {
+			return
+		}
+		start := 0
+		if n >= size {
+			start = (n-size)/step*step + step
+		}
+		for ; start < n; start += step {
+			if !yield(window(start, n)) {
+				return
+			}
+		}
+	}}
+}
+
+// Tumbling yields consecutive, non-overlapping windows of size elements.
+func Tumbling[T any](s *Stream[T], size int, opts WindowOptions) *Stream[[]T] {
+	return Sliding(s, size, size, opts)
+}
+
+// TimeWindow is a group of elements whose event times fall in [Start, End).
+// Items are kept in arrival order.
+type TimeWindow[T any] struct {
+	Start time.Time
+	End   time.Time
+	Items []T
+	// arrivals holds the arrival index of each item, so merged sessions can
+	// restore arrival order.
+	arrivals []int
+}
+
+// TimeWindowOptions configures event-time windows.
+type TimeWindowOptions[T any] struct {
+	// AllowedLateness holds windows open for this long past the latest event
+	// time seen, so out-of-order events can still be assigned to them. The
+	// watermark is the latest event time minus AllowedLateness; a window is
+	// emitted once its End is at or before the watermark. It must not be
+	// negative.
+	AllowedLateness time.Duration
+	// OnLate, if set, receives events that arrive after every window they
+	// belong to has been emitted. Late events are dropped otherwise.
+	OnLate func(T)
+}
+
+// WindowByTime groups elements into fixed-width windows by event time. A new
+// window starts every slide, so slide == width gives tumbling windows and
+// slide < width gives overlapping sliding windows; a non-positive slide means
+// width. With slide > width the windows hop and events falling between them
+// are dropped without being reported as late. Windows are aligned to the zero
+// time and emitted in Start order as the watermark passes them; windows still
+// open at the end of the stream are flushed. Empty windows are never emitted.
+func WindowByTime[T any](s *Stream[T], timestampFn func(T) time.Time, width, slide time.Duration, opts TimeWindowOptions[T]) *Stream[TimeWindow[T]] {
+	if width <= 0 {
+		panic("window width must be positive")
+	}
+	if opts.AllowedLateness < 0 {
+		panic("allowed lateness must not be negative")
+	}
+	if slide <= 0 {
+		slide = width
+	}
+	return eventTimeWindows(s, timestampFn, opts, func(pending []*TimeWindow[T], item T, _ int, ts, watermark time.Time) ([]*TimeWindow[T], bool) {
+		assigned, closed := false, false
+		for start := ts.Truncate(slide); start.Add(width).After(ts); start = start.Add(-slide) {
+			end := start.Add(width)
+			if !end.After(watermark) {
+				closed = true
+				continue
+			}
+			assigned = true
+			i, found := slices.BinarySearchFunc(pending, start, func(w *TimeWindow[T], start time.Time) int {
+				return w.Start.Compare(start)
+			})
+			if !found {
+				pending = slices.Insert(pending, i, &TimeWindow[T]{Start: start, End: end})
+			}
+			pending[i].Items = append(pending[i].Items, item)
+		}
+		return pending, closed && !assigned
+	})
+}
+
+// SessionWindows groups elements into sessions separated by at least gap of
+// event-time inactivity. Each event extends its session to its own time plus
+// gap, and an out-of-order event may merge two sessions into one.
+func SessionWindows[T any](s *Stream[T], timestampFn func(T) time.Time, gap time.Duration, opts TimeWindowOptions[T]) *Stream[TimeWindow[T]] {
+	if gap <= 0 {
+		panic("session gap must be positive")
+	}
+	if opts.AllowedLateness < 0 {
+		panic("allowed lateness must not be negative")
+	}
+	return eventTimeWindows(s, timestampFn, opts, func(pending []*TimeWindow[T], item T, arrival int, ts, watermark time.Time) ([]*TimeWindow[T], bool) {
+		session := &TimeWindow[T]{Start: ts, End: ts.Add(gap)}
+		var merged []*TimeWindow[T]
+		kept := pending[:0]
+		for _, w := range pending {
+			if w.Start.Before(session.End) && session.Start.Before(w.End) {
+				session.Start = minTime(session.Start, w.Start)
+				session.End = maxTime(session.End, w.End)
+				merged = append(merged, w)
+			} else {
+				kept = append(kept, w)
+			}
+		}
+		if len(merged) == 0 && !session.End.After(watermark) {
+			return pending, true
+		}
+		merged = append(merged, &TimeWindow[T]{Items: []T{item}, arrivals: []int{arrival}})
+		session.Items, session.arrivals = mergeArrivals(merged)
+		i, _ := slices.BinarySearchFunc(kept, session.Start, func(w *TimeWindow[T], start time.Time) int {
+			return w.Start.Compare(start)
+		})
+		return slices.Insert(kept, i, session), false
+	})
+}
+
+// eventTimeWindows drives the watermark for WindowByTime and SessionWindows.
+// assign adds an event to the pending windows, which must stay sorted by Start
+// and be non-decreasing in End, and reports whether the event was dropped
+// because the watermark had already closed its windows.
+func eventTimeWindows[T any](
+	s *Stream[T],
+	timestampFn func(T) time.Time,
+	opts TimeWindowOptions[T],
+	assign func(pending []*TimeWindow[T], item T, arrival int, ts, watermark time.Time) ([]*TimeWindow[T], bool),
+) *Stream[TimeWindow[T]] {
+	return &Stream[TimeWindow[T]]{func(yield func(TimeWindow[T]) bool) {
+		var pending []*TimeWindow[T]
+		var latest time.Time
+		seen := false
+		arrival := 0
+		for item := range s._seq {
+			arrival++
+			ts := timestampFn(item)
+			if !seen || ts.After(latest) {
+				latest, seen = ts, true
+			}
+			watermark := latest.Add(-opts.AllowedLateness)
+			var late bool
+			pending, late = assign(pending, item, arrival, ts, watermark)
+			if late && opts.OnLate != nil {
+				opts.OnLate(item)
+			}
+			for len(pending) > 0 && !pending[0].End.After(watermark) {
+				w := pending[0]
+				pending = pending[1:]
+				if !yield(*w) {
+					return
+				}
+			}
+		}
+		for _, w := range pending {
+			if !yield(*w) {
+				return
+			}
+		}
+	}}
+}
+
+// mergeArrivals combines the items of sessions into one slice ordered by
+// arrival.
+func mergeArrivals[T any](sessions []*TimeWindow[T]) ([]T, []int) {
+	type arrived struct {
+		item  T
+		index int
+	}
+	var all []arrived
+	for _, w := range sessions {
+		for i, item := range w.Items {
+			all = append(all, arrived{item, w.arrivals[i]})
+		}
+	}
+	slices.SortFunc(all, func(a, b arrived) int { return a.index - b.index })
+	items := make([]T, len(all))
+	arrivals := make([]int, len(all))
+	for i, a := range all {
+		items[i], arrivals[i] = a.item, a.index
+	}
+	return items, arrivals
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}